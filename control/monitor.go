@@ -1,7 +1,12 @@
 package control
 
 import (
+	"sync"
 	"time"
+
+	"github.com/intelsdi-x/gomit"
+
+	"github.com/intelsdi-x/pulse/core/control_event"
 )
 
 const (
@@ -9,40 +14,118 @@ const (
 	MonitorStarted
 
 	DefaultMonitorDuration = time.Second * 60
+
+	// DefaultHealthCheckFailureThreshold is how many consecutive failed
+	// health checks are tolerated before a plugin is declared dead.
+	DefaultHealthCheckFailureThreshold = 3
+
+	// DefaultHealthCheckTimeout bounds how long a single health check is
+	// allowed to take.
+	DefaultHealthCheckTimeout = time.Second * 3
+
+	// sweepInterval is how often the monitor looks for newly started or
+	// removed plugins so it can start or stop their per-plugin tickers.
+	// It is independent of, and much finer grained than, any individual
+	// plugin's HealthCheckPolicy.Interval.
+	sweepInterval = time.Second
 )
 
 type monitorState int
 
+// HealthCheckPolicy controls how often and how strictly a single plugin
+// (or class of plugin) is health checked, since different plugin types
+// tolerate very different check intervals.
+type HealthCheckPolicy struct {
+	Interval         time.Duration
+	Timeout          time.Duration
+	FailureThreshold int
+}
+
+// DefaultHealthCheckPolicy is the policy applied to a plugin that has no
+// more specific policy registered via SetPolicy.
+var DefaultHealthCheckPolicy = HealthCheckPolicy{
+	Interval:         DefaultMonitorDuration,
+	Timeout:          DefaultHealthCheckTimeout,
+	FailureThreshold: DefaultHealthCheckFailureThreshold,
+}
+
 type monitor struct {
-	State monitorState
-	quit  chan struct{}
+	State   monitorState
+	quit    chan struct{}
+	emitter gomit.Emitter
+
+	policiesMutex *sync.Mutex
+	defaultPolicy HealthCheckPolicy
+	policies      map[string]HealthCheckPolicy
+
+	watchesMutex *sync.Mutex
+	watches      map[string]chan struct{}
+
+	failuresMutex      *sync.Mutex
+	consecutiveFailure map[string]int
 }
 
 func newMonitor() *monitor {
 	m := new(monitor)
 	m.State = MonitorStopped
+	m.policiesMutex = &sync.Mutex{}
+	m.defaultPolicy = DefaultHealthCheckPolicy
+	m.policies = make(map[string]HealthCheckPolicy)
+	m.watchesMutex = &sync.Mutex{}
+	m.watches = make(map[string]chan struct{})
+	m.failuresMutex = &sync.Mutex{}
+	m.consecutiveFailure = make(map[string]int)
 	return m
 }
 
+// SetEmitter sets the gomit.Emitter the monitor uses to announce dead
+// plugins it finds during health checks.
+func (m *monitor) SetEmitter(e gomit.Emitter) {
+	m.emitter = e
+}
+
+// SetDefaultPolicy sets the HealthCheckPolicy applied to plugins with no
+// more specific policy registered via SetPolicy.
+func (m *monitor) SetDefaultPolicy(policy HealthCheckPolicy) {
+	m.policiesMutex.Lock()
+	defer m.policiesMutex.Unlock()
+	m.defaultPolicy = policy
+}
+
+// SetPolicy registers a HealthCheckPolicy for a type:name plugin key,
+// overriding the default for every instance of that plugin. An instance
+// already being watched keeps the Interval it was first watched with; its
+// Timeout and FailureThreshold come from the new policy at its next check.
+func (m *monitor) SetPolicy(key string, policy HealthCheckPolicy) {
+	m.policiesMutex.Lock()
+	defer m.policiesMutex.Unlock()
+	m.policies[key] = policy
+}
+
+func (m *monitor) policyFor(ap *availablePlugin) HealthCheckPolicy {
+	m.policiesMutex.Lock()
+	defer m.policiesMutex.Unlock()
+	if p, ok := m.policies[poolKey(ap.TypeName(), ap.Name())]; ok {
+		return p
+	}
+	return m.defaultPolicy
+}
+
 // start the monitor
 func (m *monitor) Start(availablePlugins *availablePlugins) {
-	//start a routine that will be fired every X duration looping
-	//over available plugins and firing a health check routine
-	ticker := time.NewTicker(DefaultMonitorDuration)
+	// Each plugin gets its own ticker running on its own HealthCheckPolicy
+	// interval; a lightweight sweep keeps that set of tickers in sync with
+	// plugins starting and stopping.
+	sweep := time.NewTicker(sweepInterval)
 	m.quit = make(chan struct{})
 	go func() {
 		for {
 			select {
-			case <-ticker.C:
-				availablePlugins.Lock()
-				for _, ap := range availablePlugins.Table() {
-					if ap.State == PluginRunning {
-						go ap.checkHealth()
-					}
-				}
-				availablePlugins.Unlock()
+			case <-sweep.C:
+				m.sweep(availablePlugins)
 			case <-m.quit:
-				ticker.Stop()
+				sweep.Stop()
+				m.stopAllWatches()
 				m.State = MonitorStopped
 				return
 			}
@@ -51,9 +134,126 @@ func (m *monitor) Start(availablePlugins *availablePlugins) {
 	m.State = MonitorStarted
 }
 
+// sweep starts a per-plugin watch for any running plugin that doesn't
+// have one yet, and stops the watch for any plugin no longer present.
+func (m *monitor) sweep(availablePlugins *availablePlugins) {
+	availablePlugins.Lock()
+	table := availablePlugins.Table()
+	availablePlugins.Unlock()
+
+	seen := make(map[string]bool, len(table))
+	for _, ap := range table {
+		seen[ap.String()] = true
+		if ap.State != PluginRunning {
+			continue
+		}
+
+		m.watchesMutex.Lock()
+		_, watched := m.watches[ap.String()]
+		m.watchesMutex.Unlock()
+		if !watched {
+			m.watch(ap)
+		}
+	}
+
+	m.watchesMutex.Lock()
+	for key, quit := range m.watches {
+		if !seen[key] {
+			close(quit)
+			delete(m.watches, key)
+		}
+	}
+	m.watchesMutex.Unlock()
+}
+
+// watch starts a ticker for a single plugin on its own HealthCheckPolicy
+// interval, independent of every other plugin's ticker.
+func (m *monitor) watch(ap *availablePlugin) {
+	policy := m.policyFor(ap)
+	quit := make(chan struct{})
+
+	m.watchesMutex.Lock()
+	m.watches[ap.String()] = quit
+	m.watchesMutex.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(policy.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				go m.checkHealth(ap)
+			case <-quit:
+				return
+			}
+		}
+	}()
+}
+
+func (m *monitor) stopAllWatches() {
+	m.watchesMutex.Lock()
+	defer m.watchesMutex.Unlock()
+	for key, quit := range m.watches {
+		close(quit)
+		delete(m.watches, key)
+	}
+}
+
+// checkHealth runs a single health check against an available plugin,
+// counting it as failed if it does not finish within the policy's Timeout
+// (no limit when Timeout is zero or negative), and, once it has failed its
+// policy's FailureThreshold times in a row, emits a
+// DeadAvailablePluginEvent and resets its failure count to zero.
+func (m *monitor) checkHealth(ap *availablePlugin) {
+	policy := m.policyFor(ap)
+	result := make(chan bool, 1)
+	go func() { result <- ap.checkHealth() }()
+	var timeout <-chan time.Time
+	if policy.Timeout > 0 {
+		timeout = time.After(policy.Timeout)
+	}
+	healthy := false
+	select {
+	case healthy = <-result:
+	case <-timeout:
+	}
+
+	if healthy {
+		m.failuresMutex.Lock()
+		delete(m.consecutiveFailure, ap.String())
+		m.failuresMutex.Unlock()
+		return
+	}
+
+	m.failuresMutex.Lock()
+	m.consecutiveFailure[ap.String()]++
+	failures := m.consecutiveFailure[ap.String()]
+	m.failuresMutex.Unlock()
+
+	if failures < policy.FailureThreshold {
+		return
+	}
+
+	m.failuresMutex.Lock()
+	delete(m.consecutiveFailure, ap.String())
+	m.failuresMutex.Unlock()
+
+	if m.emitter == nil {
+		return
+	}
+	event := &control_event.DeadAvailablePluginEvent{
+		Name:    ap.Name(),
+		Version: ap.Version(),
+		Type:    int(ap.Type()),
+		Key:     ap.Key(),
+		String:  ap.String(),
+	}
+	defer m.emitter.Emit(event)
+}
+
 // stop the monitor
 func (m *monitor) Stop() {
 	close(m.quit)
 	// m.Stop()
 	m.State = MonitorStopped
-}
\ No newline at end of file
+}
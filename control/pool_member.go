@@ -0,0 +1,50 @@
+package control
+
+import (
+	"sync"
+	"time"
+
+	"github.com/intelsdi-x/pulse/control/strategy"
+)
+
+// poolMember adapts an *availablePlugin to strategy.SelectablePlugin.
+// availablePlugin has no notion of routing strategies, so hit tracking is
+// kept here rather than on the plugin itself.
+type poolMember struct {
+	ap      *availablePlugin
+	mutex   sync.Mutex
+	hits    int
+	lastHit time.Time
+}
+
+func newPoolMember(ap *availablePlugin) *poolMember {
+	return &poolMember{ap: ap, lastHit: time.Now()}
+}
+
+func (m *poolMember) Key() string  { return m.ap.Key() }
+func (m *poolMember) Version() int { return m.ap.Version() }
+
+// AvailablePlugin returns the underlying *availablePlugin, for callers
+// that need to act on it directly (e.g. stopping an evicted instance).
+func (m *poolMember) AvailablePlugin() *availablePlugin { return m.ap }
+
+func (m *poolMember) HitCount() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.hits
+}
+
+func (m *poolMember) LastHitTime() time.Time {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.lastHit
+}
+
+func (m *poolMember) Hit() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.hits++
+	m.lastHit = time.Now()
+}
+
+var _ strategy.SelectablePlugin = (*poolMember)(nil)
@@ -2,7 +2,6 @@ package control
 
 import (
 	"errors"
-	"fmt"
 	"sync"
 	"time"
 
@@ -11,6 +10,7 @@ import (
 	"github.com/intelsdi-x/gomit"
 
 	"github.com/intelsdi-x/pulse/control/plugin"
+	"github.com/intelsdi-x/pulse/control/strategy"
 	"github.com/intelsdi-x/pulse/core/control_event"
 	"github.com/intelsdi-x/pulse/pkg/logger"
 )
@@ -25,6 +25,31 @@ const (
 
 	// Until more advanced decisioning on starting exists this is the max number to spawn.
 	MaximumRunningPlugins = 3
+
+	// DefaultMaxRestarts is how many times a plugin may be restarted
+	// within DefaultRestartWindow before it is disabled.
+	DefaultMaxRestarts = 5
+	// DefaultRestartWindow is the rolling window crash counts are measured
+	// against.
+	DefaultRestartWindow = time.Second * 60
+	// DefaultRestartBackoffBase is the initial delay before the first
+	// restart attempt.
+	DefaultRestartBackoffBase = time.Millisecond * 100
+	// DefaultRestartBackoffCap is the maximum delay between restart
+	// attempts, regardless of how many have already been made.
+	DefaultRestartBackoffCap = time.Second * 30
+
+	// DefaultDrainTimeout is how long Stop() waits for a plugin to exit
+	// cleanly before force-killing it (see drainAndStop).
+	DefaultDrainTimeout = time.Second * 5
+
+	// DefaultMinPoolSize is how many instances of a plugin are eagerly
+	// started the first time it is subscribed to, rather than the single
+	// instance started on each prior event.
+	DefaultMinPoolSize = 1
+	// DefaultMaxConcurrentStarts bounds how many plugin processes may be
+	// forked at once (see eagerStart).
+	DefaultMaxConcurrentStarts = 4
 )
 
 // TBD
@@ -56,6 +81,48 @@ type runner struct {
 	pluginManager    managesPlugins
 	mutex            *sync.Mutex
 	apIdCounter      *idCounter
+
+	poolsMutex      *sync.Mutex
+	pools           map[string]*strategy.Pool
+	routingStrategy strategy.Strategy
+
+	restartPolicy   restartPolicy
+	restartsMutex   *sync.Mutex
+	restartHistory  map[string][]time.Time
+	disabledMutex   *sync.Mutex
+	disabledPlugins map[string]bool
+
+	drainTimeout time.Duration
+
+	minPoolSize int
+	startSem    chan struct{}
+
+	failureObserversMutex *sync.Mutex
+	failureObservers      []FailureObserver
+}
+
+// FailureObserver is implemented by components, such as the scheduler or
+// the REST API, that need to know synchronously when a plugin fails to
+// start or is disabled, rather than only through the gomit events this
+// package already emits for the same failures.
+type FailureObserver interface {
+	CatchPluginFailure(key, errClass string, attempt int, err error)
+}
+
+// restartPolicy bounds how aggressively a crashing plugin is restarted
+// before it is given up on and disabled.
+type restartPolicy struct {
+	maxRestarts int
+	window      time.Duration
+	backoffBase time.Duration
+	backoffCap  time.Duration
+}
+
+var defaultRestartPolicy = restartPolicy{
+	maxRestarts: DefaultMaxRestarts,
+	window:      DefaultRestartWindow,
+	backoffBase: DefaultRestartBackoffBase,
+	backoffCap:  DefaultRestartBackoffCap,
 }
 
 func newRunner() *runner {
@@ -64,10 +131,210 @@ func newRunner() *runner {
 		availablePlugins: newAvailablePlugins(),
 		mutex:            &sync.Mutex{},
 		apIdCounter:      &idCounter{mutex: &sync.Mutex{}},
+		poolsMutex:       &sync.Mutex{},
+		pools:            make(map[string]*strategy.Pool),
+		routingStrategy:  strategy.LRU{},
+		restartPolicy:    defaultRestartPolicy,
+		restartsMutex:    &sync.Mutex{},
+		restartHistory:   make(map[string][]time.Time),
+		disabledMutex:    &sync.Mutex{},
+		disabledPlugins:  make(map[string]bool),
+		drainTimeout:     DefaultDrainTimeout,
+		minPoolSize:      DefaultMinPoolSize,
+		startSem:         make(chan struct{}, DefaultMaxConcurrentStarts),
+
+		failureObserversMutex: &sync.Mutex{},
 	}
 	return r
 }
 
+// SetDrainTimeout sets how long Stop() waits for each running plugin to
+// exit cleanly before force-killing it.
+func (r *runner) SetDrainTimeout(d time.Duration) {
+	r.drainTimeout = d
+}
+
+// SetMinPoolSize sets how many instances of a plugin are eagerly started
+// the first time it is subscribed to, instead of starting a single
+// instance per event and waiting for later events to fill the pool.
+func (r *runner) SetMinPoolSize(n int) {
+	r.minPoolSize = n
+}
+
+// SetMaxConcurrentStarts overrides DefaultMaxConcurrentStarts, bounding
+// how many plugin processes may be forked at once across all eagerStart
+// calls.
+func (r *runner) SetMaxConcurrentStarts(n int) {
+	r.startSem = make(chan struct{}, n)
+}
+
+// startCountFor decides how many plugin instances to start for a
+// subscription: a full pre-warmed pool (minPoolSize, capped at
+// MaximumRunningPlugins) if none are running yet, otherwise just one more.
+func (r *runner) startCountFor(pool *strategy.Pool) int {
+	if pool != nil && pool.Count(0) > 0 {
+		return 1
+	}
+	count := r.minPoolSize
+	if count > MaximumRunningPlugins {
+		count = MaximumRunningPlugins
+	}
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+// PreloadPlugin eagerly starts up to count instances of the loaded plugin
+// identified by key (type:name:version), ahead of any subscription
+// requesting it; fewer are started if the pool would otherwise exceed
+// MaximumRunningPlugins (see eagerStart). r.mutex is held only for the LoadedPlugins() lookup,
+// the same shared cursor HandleGomitEvent's subscription cases and
+// handleDeadAvailablePlugin iterate, not across the eagerStart call.
+func (r *runner) PreloadPlugin(key string, count int) error {
+	r.mutex.Lock()
+	var path, typeName, name string
+	found := false
+	for r.pluginManager.LoadedPlugins().Next() {
+		_, lp := r.pluginManager.LoadedPlugins().Item()
+		if lp.Key() != key {
+			continue
+		}
+		path, typeName, name = lp.Path, lp.TypeName(), lp.Name()
+		found = true
+		break
+	}
+	r.mutex.Unlock()
+
+	if !found {
+		return errors.New("no loaded plugin found for key (" + key + ")")
+	}
+	return r.eagerStart(key, path, r.getPool(typeName, name), count)
+}
+
+// eagerStart starts up to want instances of a plugin concurrently, bounded
+// by startSem so a burst of subscriptions can't fork-bomb the host. The
+// actual count is first reserved against pool so concurrent callers can't
+// together overshoot MaximumRunningPlugins. Each instance goes through the
+// same supervised restart path as a normal start.
+func (r *runner) eagerStart(key, path string, pool *strategy.Pool, want int) error {
+	count := pool.Reserve(MaximumRunningPlugins, want)
+	if count <= 0 {
+		return nil
+	}
+	defer pool.Release(count)
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.startSem <- struct{}{}
+			defer func() { <-r.startSem }()
+
+			_, err := r.startPluginSupervised(key, func() (executablePlugin, error) {
+				return plugin.NewExecutablePlugin(r.pluginManager.GenerateArgs(path), path)
+			})
+			if err != nil {
+				errCh <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+
+	var errs []string
+	for e := range errCh {
+		errs = append(errs, e.Error())
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// dispatchSubscription decides whether an already-running instance of a
+// plugin can service a new subscription, via pool.SelectAP, or whether
+// more instances need to be started. Every resolved subscription counts
+// against pool.Subscribers(), regardless of which branch handles it, so
+// handleDeadAvailablePlugin's replacement gate actually reflects demand
+// instead of staying at zero for pools that never hit the overflow path.
+func (r *runner) dispatchSubscription(key, path string, version int, pool *strategy.Pool) {
+	pool.Subscribe()
+
+	if pool.Count(0) >= MaximumRunningPlugins {
+		if selected, err := pool.SelectAP(r.routingStrategy, version); err == nil {
+			logger.Debugf("runner.events", "routed subscription to existing instance (%s)", selected.Key())
+			return
+		}
+
+		// SelectAP only searches the pinned version; the aggregate cap
+		// can be held entirely by other versions (e.g. right after an
+		// upgrade), which would otherwise starve this version forever.
+		// Evict the least-recently-hit instance of another version to
+		// make room, then fall through to start this version below.
+		victim := pool.EvictOldest(version)
+		if victim == nil {
+			err := errors.New("no running instance to route to and no other version to evict (" + key + ")")
+			logger.Error("runner.events", err.Error())
+			r.emitSubscriptionFailed(key, version, err)
+			return
+		}
+		if pm, ok := victim.(*poolMember); ok {
+			if err := r.drainAndStop(pm.AvailablePlugin()); err != nil {
+				logger.Error("runner.events", "failed to stop evicted instance ("+victim.Key()+"): "+err.Error())
+			}
+		}
+	}
+
+	if err := r.eagerStart(key, path, pool, r.startCountFor(pool)); err != nil {
+		logger.Error("runner.events", err.Error())
+		r.emitSubscriptionFailed(key, version, err)
+	}
+}
+
+// SetRestartPolicy configures the crash budget and backoff schedule
+// startPluginSupervised uses when a plugin repeatedly fails to start.
+// maxRestarts is how many restarts are tolerated within window before the
+// plugin is disabled; backoffBase and backoffCap bound the exponential
+// delay between attempts.
+func (r *runner) SetRestartPolicy(maxRestarts int, window, backoffBase, backoffCap time.Duration) {
+	r.restartPolicy = restartPolicy{
+		maxRestarts: maxRestarts,
+		window:      window,
+		backoffBase: backoffBase,
+		backoffCap:  backoffCap,
+	}
+}
+
+// SetRoutingStrategy sets the policy used to select which running
+// instance of a plugin in a pool services the next subscription. Default
+// is strategy.LRU.
+func (r *runner) SetRoutingStrategy(s strategy.Strategy) {
+	r.routingStrategy = s
+}
+
+// poolKey returns the type:name key (version-agnostic) a plugin's
+// version-spanning pool is tracked under.
+func poolKey(pluginType, name string) string {
+	return pluginType + ":" + name
+}
+
+// getPool returns the strategy.Pool for a type:name key, creating it if
+// this is the first time the key has been seen.
+func (r *runner) getPool(pluginType, name string) *strategy.Pool {
+	key := poolKey(pluginType, name)
+	r.poolsMutex.Lock()
+	defer r.poolsMutex.Unlock()
+	p, ok := r.pools[key]
+	if !ok {
+		p = strategy.New(key)
+		r.pools[key] = p
+	}
+	return p
+}
+
 func (r *runner) SetMetricCatalog(c catalogsMetrics) {
 	r.metricCatalog = c
 }
@@ -88,6 +355,48 @@ func (r *runner) Monitor() *monitor {
 	return r.monitor
 }
 
+// healthPolicyProvider is implemented by plugin managers that want to seed
+// the monitor with a non-default HealthCheckPolicy on Start(), rather than
+// every plugin using monitor.DefaultHealthCheckPolicy.
+type healthPolicyProvider interface {
+	DefaultHealthCheckPolicy() HealthCheckPolicy
+}
+
+// SetHealthPolicy registers a HealthCheckPolicy for a plugin type:name key,
+// overriding the monitor's default for every instance of that plugin. An
+// instance the monitor is already watching keeps its old check Interval
+// (see monitor.SetPolicy).
+func (r *runner) SetHealthPolicy(key string, policy HealthCheckPolicy) {
+	r.monitor.SetPolicy(key, policy)
+}
+
+// AddFailureObserver registers a FailureObserver to be notified
+// synchronously whenever a plugin start attempt fails, a plugin is
+// disabled after exceeding its restart budget, or a subscription can't be
+// routed or serviced (see emitSubscriptionFailed), so callers such as the
+// scheduler or REST API can surface the failure without having to
+// register their own gomit handler for it.
+func (r *runner) AddFailureObserver(o FailureObserver) {
+	r.failureObserversMutex.Lock()
+	defer r.failureObserversMutex.Unlock()
+	r.failureObservers = append(r.failureObservers, o)
+}
+
+// notifyFailureObservers calls every registered FailureObserver with a
+// plugin failure. errClass is "disabled" when the failure is that the
+// plugin's restart budget was exceeded rather than a single failed start,
+// and "subscription" when a subscription could not be routed or serviced.
+func (r *runner) notifyFailureObservers(key, errClass string, attempt int, err error) {
+	r.failureObserversMutex.Lock()
+	observers := make([]FailureObserver, len(r.failureObservers))
+	copy(observers, r.failureObservers)
+	r.failureObserversMutex.Unlock()
+
+	for _, o := range observers {
+		o.CatchPluginFailure(key, errClass, attempt, err)
+	}
+}
+
 // Adds Delegates (gomit.Delegator) for adding Runner handlers to on Start and
 // unregistration on Stop.
 func (r *runner) AddDelegates(delegates ...gomit.Delegator) {
@@ -112,6 +421,10 @@ func (r *runner) Start() error {
 	}
 
 	// Start the monitor
+	r.monitor.SetEmitter(r.emitter)
+	if p, ok := r.pluginManager.(healthPolicyProvider); ok {
+		r.monitor.SetDefaultPolicy(p.DefaultHealthCheckPolicy())
+	}
 	r.monitor.Start(r.availablePlugins)
 
 	logger.Debug("runner.start", "started")
@@ -122,10 +435,32 @@ func (r *runner) Start() error {
 func (r *runner) Stop() []error {
 	var errs []error
 
-	// Stop the monitor
-	r.monitor.Stop()
+	// Stop every running plugin before the monitor, so health checks
+	// aren't still firing against plugins mid-shutdown.
+	r.availablePlugins.Lock()
+	aps := r.availablePlugins.Table()
+	r.availablePlugins.Unlock()
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(aps))
+	for _, ap := range aps {
+		wg.Add(1)
+		go func(ap *availablePlugin) {
+			defer wg.Done()
+			if err := r.drainAndStop(ap); err != nil {
+				errCh <- err
+			}
+		}(ap)
+	}
+	wg.Wait()
+	close(errCh)
+	for e := range errCh {
+		errs = append(errs, e)
+	}
 
-	// TODO: Actually stop the plugins
+	// Stop the monitor only once plugins are down so health checks don't
+	// race with shutdown.
+	r.monitor.Stop()
 
 	// For each delegate unregister needed handlers
 	for _, del := range r.delegates {
@@ -138,6 +473,43 @@ func (r *runner) Stop() []error {
 	return errs
 }
 
+// drainAndStop asks a single plugin to exit, giving it up to
+// r.drainTimeout to finish any in-flight collect/publish RPCs before
+// force-killing it, and removes it from the available plugin table.
+func (r *runner) drainAndStop(ap *availablePlugin) error {
+	logger.Debugf("runner.stop", "draining available plugin (%s)", ap.String())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ap.Stop("runner stopping")
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			logger.Errorf("runner.stop", "plugin (%s) failed to stop cleanly, force killing: %v", ap.String(), err)
+			if err := ap.Kill("stop failed: " + err.Error()); err != nil {
+				return err
+			}
+		}
+	case <-time.After(r.drainTimeout):
+		logger.Errorf("runner.stop", "plugin (%s) did not drain within %s, force killing", ap.String(), r.drainTimeout)
+		if err := ap.Kill("drain timeout exceeded"); err != nil {
+			return err
+		}
+	}
+
+	if err := r.availablePlugins.Remove(ap); err != nil {
+		return err
+	}
+	r.getPool(ap.TypeName(), ap.Name()).Remove(newPoolMember(ap))
+
+	if r.emitter != nil {
+		r.emitter.Emit(&control_event.PluginStopEvent{Key: ap.Key()})
+	}
+	return nil
+}
+
 func (r *runner) startPlugin(p executablePlugin) (*availablePlugin, error) {
 	e := p.Start()
 	if e != nil {
@@ -163,21 +535,169 @@ func (r *runner) startPlugin(p executablePlugin) (*availablePlugin, error) {
 	// build availablePlugin
 	ap, err := newAvailablePlugin(resp, r.apIdCounter.Next(), r.emitter)
 	if err != nil {
-		return nil, err
+		return nil, errors.New("error while building available plugin: " + err.Error())
 	}
 
 	// Ping through client
 	err = ap.Client.Ping()
 	if err != nil {
-		return nil, err
+		return nil, errors.New("error while pinging plugin: " + err.Error())
 	}
 
 	r.availablePlugins.Insert(ap)
+	r.getPool(ap.TypeName(), ap.Name()).Insert(newPoolMember(ap))
 	logger.Infof("runner.events", "available plugin started (%s)", ap.String())
 
 	return ap, nil
 }
 
+// startPluginSupervised starts a plugin, retrying with exponential backoff
+// on failure up to the configured restart budget. Once a plugin's restart
+// history exceeds that budget its key is recorded as disabled (see
+// disablePlugin) and a PluginDisabledEvent is emitted instead of being
+// retried further.
+func (r *runner) startPluginSupervised(key string, build func() (executablePlugin, error)) (*availablePlugin, error) {
+	if r.isDisabled(key) {
+		r.emitStartFailed(key, "disabled", 0, errors.New("plugin is disabled"))
+		return nil, errors.New("plugin (" + key + ") is disabled, not starting")
+	}
+
+	attempt := 0
+	for {
+		ep, err := build()
+		if err == nil {
+			var ap *availablePlugin
+			ap, err = r.startPlugin(ep)
+			if err == nil {
+				r.resetRestartHistory(key)
+				return ap, nil
+			}
+		}
+
+		logger.Errorf("runner.supervise", "plugin (%s) failed to start (attempt %d): %v", key, attempt+1, err)
+		r.emitStartFailed(key, classifyStartError(err), attempt+1, err)
+
+		if r.overRestartBudget(key) {
+			r.disablePlugin(key, attempt+1, err)
+			return nil, errors.New("plugin (" + key + ") exceeded its restart budget and has been disabled")
+		}
+
+		time.Sleep(r.nextBackoff(attempt))
+		attempt++
+	}
+}
+
+// classifyStartError buckets a startup error by the phase it occurred in,
+// matching the error prefixes startPlugin returns.
+func classifyStartError(err error) string {
+	if err == nil {
+		return ""
+	}
+	switch {
+	case strings.HasPrefix(err.Error(), "error while starting plugin:"):
+		return "start"
+	case strings.HasPrefix(err.Error(), "error while waiting for response:"), strings.HasPrefix(err.Error(), "no reponse object returned"), strings.HasPrefix(err.Error(), "plugin could not start error:"):
+		return "response"
+	case strings.HasPrefix(err.Error(), "error while pinging plugin:"):
+		return "ping"
+	default:
+		return "unknown"
+	}
+}
+
+// emitStartFailed announces a failed start attempt through r.emitter so
+// the scheduler and REST API can surface it instead of the daemon
+// crashing.
+func (r *runner) emitStartFailed(key, errClass string, attempt int, err error) {
+	if err == nil {
+		return
+	}
+	if r.emitter != nil {
+		r.emitter.Emit(&control_event.PluginStartFailedEvent{
+			Key:        key,
+			Error:      err.Error(),
+			ErrorClass: errClass,
+			Attempt:    attempt,
+		})
+	}
+	r.notifyFailureObservers(key, errClass, attempt, err)
+}
+
+// emitSubscriptionFailed announces a subscription that could not be
+// routed to a running instance or serviced by starting a new one,
+// mirroring emitStartFailed so the scheduler and REST API see a dropped
+// subscription the same way they see a failed start.
+func (r *runner) emitSubscriptionFailed(key string, version int, err error) {
+	if err == nil {
+		return
+	}
+	if r.emitter != nil {
+		r.emitter.Emit(&control_event.PluginSubscriptionFailedEvent{
+			PluginName:    key,
+			PluginVersion: version,
+			Error:         err.Error(),
+		})
+	}
+	r.notifyFailureObservers(key, "subscription", 0, err)
+}
+
+// nextBackoff returns the exponential delay before the (attempt+1)'th
+// restart attempt, capped at restartPolicy.backoffCap.
+func (r *runner) nextBackoff(attempt int) time.Duration {
+	d := r.restartPolicy.backoffBase << uint(attempt)
+	if d > r.restartPolicy.backoffCap || d <= 0 {
+		return r.restartPolicy.backoffCap
+	}
+	return d
+}
+
+// overRestartBudget records a restart attempt for key and reports whether
+// it has now exceeded restartPolicy.maxRestarts within restartPolicy.window.
+func (r *runner) overRestartBudget(key string) bool {
+	now := time.Now()
+	r.restartsMutex.Lock()
+	defer r.restartsMutex.Unlock()
+
+	cutoff := now.Add(-r.restartPolicy.window)
+	history := r.restartHistory[key]
+	pruned := history[:0]
+	for _, t := range history {
+		if t.After(cutoff) {
+			pruned = append(pruned, t)
+		}
+	}
+	pruned = append(pruned, now)
+	r.restartHistory[key] = pruned
+
+	return len(pruned) > r.restartPolicy.maxRestarts
+}
+
+func (r *runner) resetRestartHistory(key string) {
+	r.restartsMutex.Lock()
+	defer r.restartsMutex.Unlock()
+	delete(r.restartHistory, key)
+}
+
+func (r *runner) isDisabled(key string) bool {
+	r.disabledMutex.Lock()
+	defer r.disabledMutex.Unlock()
+	return r.disabledPlugins[key]
+}
+
+// disablePlugin marks a plugin key as disabled so future start attempts
+// are refused, and emits a PluginDisabledEvent to let operators know.
+func (r *runner) disablePlugin(key string, attempt int, cause error) {
+	r.disabledMutex.Lock()
+	r.disabledPlugins[key] = true
+	r.disabledMutex.Unlock()
+
+	logger.Errorf("runner.supervise", "plugin (%s) disabled after exceeding restart budget", key)
+	if r.emitter != nil {
+		r.emitter.Emit(&control_event.PluginDisabledEvent{Key: key})
+	}
+	r.notifyFailureObservers(key, "disabled", attempt, cause)
+}
+
 func (r *runner) stopPlugin(reason string, ap *availablePlugin) error {
 	err := ap.Stop(reason)
 	if err != nil {
@@ -190,83 +710,152 @@ func (r *runner) stopPlugin(reason string, ap *availablePlugin) error {
 	return nil
 }
 
-// Empty handler acting as placeholder until implementation. This helps tests
-// pass to ensure registration works.
+// HandleGomitEvent routes subscription, unsubscription and dead-plugin
+// events to the right strategy.Pool. r.mutex is only held for the cheap
+// LoadedPlugins() iteration; the potentially slow start path runs
+// unlocked so a crash-looping plugin can't freeze unrelated events.
 func (r *runner) HandleGomitEvent(e gomit.Event) {
 
 	switch v := e.Body.(type) {
 	case *control_event.PublisherSubscriptionEvent:
-		r.mutex.Lock()
-		defer r.mutex.Unlock()
 		logger.Debugf("runner.events", "handling publisher subscription event (%v:v%v)", v.PluginName, v.PluginVersion)
 
+		r.mutex.Lock()
+		var key, path string
+		found := false
 		for r.pluginManager.LoadedPlugins().Next() {
 			_, lp := r.pluginManager.LoadedPlugins().Item()
-			logger.Debugf("runner.events", "subscription request name: %v version: %v", v.PluginName, v.PluginVersion)
-			logger.Debugf("runner.events", "loaded plugin name: %v version: %v type: %v", lp.Name(), lp.Version(), lp.TypeName())
 			if lp.TypeName() == "publisher" && lp.Name() == v.PluginName && lp.Version() == v.PluginVersion {
-				pool := r.availablePlugins.Publishers.GetPluginPool(lp.Key())
-				ok := checkPool(pool, lp.Key())
-				if !ok {
-					return
-				}
-
-				ePlugin, err := plugin.NewExecutablePlugin(r.pluginManager.GenerateArgs(lp.Path), lp.Path)
-				_, err = r.startPlugin(ePlugin)
-				if err != nil {
-					fmt.Println(err)
-					panic(err)
-				}
+				key, path = lp.Key(), lp.Path
+				found = true
+				break
 			}
-
 		}
+		r.mutex.Unlock()
+
+		if !found {
+			logger.Debugf("runner.events", "no loaded plugin for publisher subscription (%s v%d)", v.PluginName, v.PluginVersion)
+			if r.emitter != nil {
+				r.emitter.Emit(&control_event.PluginSubscriptionFailedEvent{
+					PluginName:    v.PluginName,
+					PluginVersion: v.PluginVersion,
+					Error:         "no loaded publisher plugin matches the subscription",
+				})
+			}
+			return
+		}
+		r.dispatchSubscription(key, path, v.PluginVersion, r.getPool("publisher", v.PluginName))
 	case *control_event.MetricSubscriptionEvent:
-		r.mutex.Lock()
-		defer r.mutex.Unlock()
 		logger.Debugf("runner.events", "handling metric subscription event (%s v%d)", strings.Join(v.MetricNamespace, "/"), v.Version)
 
-		// Our logic here is simple for alpha. We should replace with parameter managed logic.
-		//
-		// 1. Get the loaded plugin for the subscription.
-		// 2. Check that at least one available plugin of that type is running
-		// 3. If not start one
-
 		mt, err := r.metricCatalog.Get(v.MetricNamespace, v.Version)
 		if err != nil {
-			// log this error # TODO with logging
-			fmt.Println(err)
+			logger.Debugf("runner.events", "no plugin for (%s v%d): %v", strings.Join(v.MetricNamespace, "/"), v.Version, err)
+			if r.emitter != nil {
+				r.emitter.Emit(&control_event.PluginSubscriptionFailedEvent{
+					PluginName:    strings.Join(v.MetricNamespace, "/"),
+					PluginVersion: v.Version,
+					Error:         err.Error(),
+				})
+			}
 			return
 		}
 		logger.Debugf("runner.events", "plugin is (%s) for (%s v%d)", mt.Plugin.Key(), strings.Join(v.MetricNamespace, "/"), v.Version)
 
-		pool := r.availablePlugins.Collectors.GetPluginPool(mt.Plugin.Key())
-		ok := checkPool(pool, mt.Plugin.Key())
-		if !ok {
+		r.dispatchSubscription(mt.Plugin.Key(), mt.Plugin.Path, mt.Plugin.Version(), r.getPool(mt.Plugin.TypeName(), mt.Plugin.Name()))
+	case *control_event.PublisherUnsubscriptionEvent:
+		logger.Debugf("runner.events", "handling publisher unsubscription event (%v:v%v)", v.PluginName, v.PluginVersion)
+		r.getPool("publisher", v.PluginName).Unsubscribe()
+	case *control_event.MetricUnsubscriptionEvent:
+		logger.Debugf("runner.events", "handling metric unsubscription event (%s v%d)", strings.Join(v.MetricNamespace, "/"), v.Version)
+		mt, err := r.metricCatalog.Get(v.MetricNamespace, v.Version)
+		if err != nil {
+			logger.Debugf("runner.events", "no plugin for (%s v%d): %v", strings.Join(v.MetricNamespace, "/"), v.Version, err)
 			return
 		}
+		r.getPool(mt.Plugin.TypeName(), mt.Plugin.Name()).Unsubscribe()
+	case *control_event.DeadAvailablePluginEvent:
+		logger.Debugf("runner.events", "handling dead available plugin event (%s)", v.String)
+		r.handleDeadAvailablePlugin(v)
+	}
+}
 
-		ePlugin, err := plugin.NewExecutablePlugin(r.pluginManager.GenerateArgs(mt.Plugin.Path), mt.Plugin.Path)
-		if err != nil {
-			logger.Debugf("runner:HandleGomitEvent", "Plugin %v (ver %v) error: %v", mt.Plugin.Name(), mt.Plugin.Version(), err)
-			fmt.Println(err)
+// handleDeadAvailablePlugin removes a dead plugin from the available
+// plugin table and its pool and, if the remaining pool can no longer
+// satisfy its existing subscribers, starts a replacement via eagerStart.
+// It looks up both the dead instance and its loaded plugin via the same
+// Table()/Next()/Item() iterator pattern HandleGomitEvent's subscription
+// cases use, rather than an indexed Get, since neither availablePlugins
+// nor LoadedPlugins expose one. r.mutex is held only around the
+// LoadedPlugins() lookup, the same shared cursor the subscription cases
+// and PreloadPlugin use, not across the eagerStart call: a crash-looping
+// plugin must not block unrelated events from being handled.
+func (r *runner) handleDeadAvailablePlugin(v *control_event.DeadAvailablePluginEvent) {
+	r.availablePlugins.Lock()
+	table := r.availablePlugins.Table()
+	r.availablePlugins.Unlock()
+
+	var ap *availablePlugin
+	for _, a := range table {
+		if a.String() == v.String {
+			ap = a
+			break
 		}
-		_, err = r.startPlugin(ePlugin)
-		if err != nil {
-			logger.Debugf("runner:HandleGomitEvent", "Plugin %v (ver %v) start error: %v", mt.Plugin.Name(), mt.Plugin.Version(), err)
-			panic(err)
+	}
+
+	pool := r.getPool(pluginTypeName(v.Type), v.Name)
+	if ap == nil {
+		logger.Debugf("runner.events", "dead plugin (%s) already removed", v.Key)
+	} else {
+		if err := r.availablePlugins.Remove(ap); err != nil {
+			logger.Error("runner.events", err.Error())
 		}
+		pool.Remove(newPoolMember(ap))
 	}
-}
 
-func checkPool(pool *availablePluginPool, key string) bool {
-	if pool != nil && pool.Count() >= MaximumRunningPlugins {
-		logger.Debugf("runner.events", "(%s) has %d available plugin running (need %d)", key, pool.Count(), MaximumRunningPlugins)
-		return false
+	r.mutex.Lock()
+	var path string
+	found := false
+	for r.pluginManager.LoadedPlugins().Next() {
+		_, lp := r.pluginManager.LoadedPlugins().Item()
+		if lp.Key() == v.Key {
+			path = lp.Path
+			found = true
+			break
+		}
 	}
-	if pool == nil {
-		logger.Debugf("runner.events", "not enough available plugins (%d) running for (%s) need %d", 0, key, MaximumRunningPlugins)
-	} else {
-		logger.Debugf("runner.events", "not enough available plugins (%d) running for (%s) need %d", pool.Count(), key, MaximumRunningPlugins)
+	r.mutex.Unlock()
+
+	if !found {
+		logger.Debugf("runner.events", "no loaded plugin for dead plugin (%s), not restarting", v.Key)
+		return
+	}
+
+	if shouldReplaceDeadPlugin(pool) {
+		if err := r.eagerStart(v.Key, path, pool, 1); err != nil {
+			logger.Error("runner.events", "failed to restart dead plugin: "+err.Error())
+		}
+	}
+}
+
+// shouldReplaceDeadPlugin reports whether a dead plugin's pool still has
+// active subscribers and room under the aggregate cap, in which case it
+// is worth starting a replacement instance for.
+func shouldReplaceDeadPlugin(pool *strategy.Pool) bool {
+	return pool.Subscribers() > 0 && pool.Count(0) < MaximumRunningPlugins
+}
+
+// pluginTypeName maps a plugin.PluginType to its string representation,
+// matching what lp.TypeName() returns for loaded plugins.
+func pluginTypeName(t int) string {
+	switch plugin.PluginType(t) {
+	case plugin.CollectorPluginType:
+		return "collector"
+	case plugin.PublisherPluginType:
+		return "publisher"
+	case plugin.ProcessorPluginType:
+		return "processor"
+	default:
+		return "unknown"
 	}
-	return true
 }
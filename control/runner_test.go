@@ -0,0 +1,226 @@
+package control
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/intelsdi-x/pulse/control/strategy"
+)
+
+// drainAndStop, eagerStart and startPluginSupervised all operate on the
+// concrete *availablePlugin type and the managesPlugins plugin manager,
+// neither of which this package defines in this checkout - they're
+// expected to come from the rest of the pulse daemon. There's nothing to
+// construct a fake of, so their drain/force-kill and supervised-start
+// behavior isn't covered here; shouldReplaceDeadPlugin and
+// dispatchSubscription's routing decision below are covered since they
+// only touch the real, self-contained strategy.Pool.
+
+// newTestRunner builds a *runner with just the fields the restart
+// supervisor touches, avoiding the plugin-manager/available-plugins
+// wiring newRunner() requires.
+func newTestRunner(policy restartPolicy) *runner {
+	return &runner{
+		restartPolicy:         policy,
+		restartsMutex:         &sync.Mutex{},
+		restartHistory:        make(map[string][]time.Time),
+		disabledMutex:         &sync.Mutex{},
+		disabledPlugins:       make(map[string]bool),
+		failureObserversMutex: &sync.Mutex{},
+	}
+}
+
+func TestNextBackoffExponentialWithCap(t *testing.T) {
+	r := newTestRunner(restartPolicy{
+		backoffBase: time.Millisecond * 100,
+		backoffCap:  time.Second * 30,
+	})
+
+	cases := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{0, time.Millisecond * 100},
+		{1, time.Millisecond * 200},
+		{2, time.Millisecond * 400},
+		{20, time.Second * 30}, // shifted past the cap
+	}
+	for _, c := range cases {
+		if got := r.nextBackoff(c.attempt); got != c.want {
+			t.Errorf("nextBackoff(%d) = %v, want %v", c.attempt, got, c.want)
+		}
+	}
+}
+
+func TestOverRestartBudget(t *testing.T) {
+	r := newTestRunner(restartPolicy{
+		maxRestarts: 2,
+		window:      time.Minute,
+	})
+
+	if r.overRestartBudget("p1") {
+		t.Fatal("expected first restart to be within budget")
+	}
+	if r.overRestartBudget("p1") {
+		t.Fatal("expected second restart to be within budget")
+	}
+	if !r.overRestartBudget("p1") {
+		t.Fatal("expected third restart within the window to exceed the budget")
+	}
+
+	// A different key has its own independent history.
+	if r.overRestartBudget("p2") {
+		t.Fatal("expected unrelated plugin key to have a fresh budget")
+	}
+}
+
+func TestOverRestartBudgetPrunesOldRestarts(t *testing.T) {
+	r := newTestRunner(restartPolicy{
+		maxRestarts: 1,
+		window:      time.Minute,
+	})
+
+	r.restartsMutex.Lock()
+	r.restartHistory["p1"] = []time.Time{time.Now().Add(-time.Hour)}
+	r.restartsMutex.Unlock()
+
+	if r.overRestartBudget("p1") {
+		t.Fatal("expected restart outside the window to be pruned before budget check")
+	}
+}
+
+func TestResetRestartHistory(t *testing.T) {
+	r := newTestRunner(restartPolicy{maxRestarts: 1, window: time.Minute})
+	r.overRestartBudget("p1")
+	r.resetRestartHistory("p1")
+
+	if r.overRestartBudget("p1") {
+		t.Fatal("expected restart history to be cleared")
+	}
+}
+
+func TestClassifyStartError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{errors.New("error while starting plugin: boom"), "start"},
+		{errors.New("error while waiting for response: boom"), "response"},
+		{errors.New("no reponse object returned from plugin"), "response"},
+		{errors.New("plugin could not start error: boom"), "response"},
+		{errors.New("error while pinging plugin: boom"), "ping"},
+		{errors.New("error while building available plugin: boom"), "unknown"},
+		{errors.New("something else"), "unknown"},
+	}
+	for _, c := range cases {
+		if got := classifyStartError(c.err); got != c.want {
+			t.Errorf("classifyStartError(%q) = %q, want %q", c.err, got, c.want)
+		}
+	}
+}
+
+func TestDisablePluginMarksKeyDisabled(t *testing.T) {
+	r := newTestRunner(defaultRestartPolicy)
+
+	if r.isDisabled("p1") {
+		t.Fatal("expected plugin to start out enabled")
+	}
+
+	r.disablePlugin("p1", 6, errors.New("boom"))
+
+	if !r.isDisabled("p1") {
+		t.Fatal("expected plugin to be disabled")
+	}
+}
+
+func TestFailureObserverNotifiedOnDisable(t *testing.T) {
+	r := newTestRunner(defaultRestartPolicy)
+
+	var gotKey, gotClass string
+	var gotAttempt int
+	r.AddFailureObserver(failureObserverFunc(func(key, errClass string, attempt int, err error) {
+		gotKey, gotClass, gotAttempt = key, errClass, attempt
+	}))
+
+	r.disablePlugin("p1", 6, errors.New("boom"))
+
+	if gotKey != "p1" || gotClass != "disabled" || gotAttempt != 6 {
+		t.Fatalf("expected observer to see (p1, disabled, 6), got (%s, %s, %d)", gotKey, gotClass, gotAttempt)
+	}
+}
+
+// failureObserverFunc adapts a plain function to FailureObserver for tests.
+type failureObserverFunc func(key, errClass string, attempt int, err error)
+
+func (f failureObserverFunc) CatchPluginFailure(key, errClass string, attempt int, err error) {
+	f(key, errClass, attempt, err)
+}
+
+// mockSelectablePlugin is a minimal strategy.SelectablePlugin, standing
+// in for a poolMember-wrapped *availablePlugin in tests that exercise
+// Pool-backed runner logic without the plugin-manager/available-plugins
+// wiring newRunner() requires.
+type mockSelectablePlugin struct {
+	key     string
+	version int
+	lastHit time.Time
+}
+
+func (m *mockSelectablePlugin) Key() string            { return m.key }
+func (m *mockSelectablePlugin) Version() int           { return m.version }
+func (m *mockSelectablePlugin) HitCount() int          { return 0 }
+func (m *mockSelectablePlugin) LastHitTime() time.Time { return m.lastHit }
+func (m *mockSelectablePlugin) Hit()                   {}
+
+func TestShouldReplaceDeadPluginRequiresSubscribersAndRoom(t *testing.T) {
+	pool := strategy.New("collector:foo")
+	pool.Insert(&mockSelectablePlugin{key: "collector:foo:1:a", version: 1})
+
+	if shouldReplaceDeadPlugin(pool) {
+		t.Fatal("expected no replacement with zero subscribers")
+	}
+
+	pool.Subscribe()
+	if !shouldReplaceDeadPlugin(pool) {
+		t.Fatal("expected replacement once a subscriber exists and the pool has room")
+	}
+
+	for i := 0; i < MaximumRunningPlugins-1; i++ {
+		pool.Insert(&mockSelectablePlugin{key: fmt.Sprintf("collector:foo:1:extra%d", i), version: 1})
+	}
+	if shouldReplaceDeadPlugin(pool) {
+		t.Fatal("expected no replacement once the pool is at MaximumRunningPlugins")
+	}
+}
+
+// newTestPoolRunner builds a *runner with just the pool bookkeeping
+// dispatchSubscription's routing decision touches, so it can be tested
+// without the plugin-manager/available-plugins wiring newRunner()
+// requires.
+func newTestPoolRunner() *runner {
+	return &runner{
+		poolsMutex:      &sync.Mutex{},
+		pools:           make(map[string]*strategy.Pool),
+		routingStrategy: strategy.LRU{},
+	}
+}
+
+func TestDispatchSubscriptionCountsSubscriberWhenRoutedToExistingInstance(t *testing.T) {
+	r := newTestPoolRunner()
+	pool := r.getPool("collector", "foo")
+	for i := 0; i < MaximumRunningPlugins; i++ {
+		pool.Insert(&mockSelectablePlugin{key: fmt.Sprintf("collector:foo:1:%d", i), version: 1})
+	}
+
+	// The pool is already at MaximumRunningPlugins for version 1, so this
+	// must route to an existing instance rather than calling eagerStart
+	// (which would need the plugin-manager wiring this runner lacks).
+	r.dispatchSubscription("collector:foo:1", "/path", 1, pool)
+
+	if got := pool.Subscribers(); got != 1 {
+		t.Fatalf("expected the subscription to be counted even when routed to an existing instance, got %d", got)
+	}
+}
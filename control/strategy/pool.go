@@ -0,0 +1,303 @@
+// Package strategy selects which running instance of a plugin services a
+// subscription, and tracks every version of a plugin under a single
+// type:name Pool.
+package strategy
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrPoolEmpty is returned when a Strategy is asked to select from a
+	// pool with no running candidates.
+	ErrPoolEmpty = errors.New("strategy: pool is empty")
+)
+
+// SelectablePlugin is the subset of an available plugin's behavior a
+// Strategy needs in order to choose among the candidates in a Pool. A
+// type that only has a Key() and Version() (such as *availablePlugin)
+// does not satisfy this on its own; Hit must be called by whatever
+// selects it so HitCount/LastHitTime reflect real usage.
+type SelectablePlugin interface {
+	// Key returns the type:name:version key of the plugin.
+	Key() string
+	// Version returns the version of the plugin.
+	Version() int
+	// HitCount returns the number of times this plugin has been selected.
+	HitCount() int
+	// LastHitTime returns the last time this plugin was selected.
+	LastHitTime() time.Time
+	// Hit records that this plugin was just selected to service a
+	// subscription, for HitCount/LastHitTime to reflect.
+	Hit()
+}
+
+// Strategy selects one candidate out of a pool to service the next
+// subscription.
+type Strategy interface {
+	Select([]SelectablePlugin) (SelectablePlugin, error)
+}
+
+// LRU selects the candidate that was hit least recently.
+type LRU struct{}
+
+func (LRU) Select(cands []SelectablePlugin) (SelectablePlugin, error) {
+	if len(cands) == 0 {
+		return nil, ErrPoolEmpty
+	}
+	oldest := cands[0]
+	for _, c := range cands[1:] {
+		if c.LastHitTime().Before(oldest.LastHitTime()) {
+			oldest = c
+		}
+	}
+	return oldest, nil
+}
+
+// RoundRobin cycles through candidates in rotating order.
+type RoundRobin struct {
+	mutex sync.Mutex
+	next  int
+}
+
+func (r *RoundRobin) Select(cands []SelectablePlugin) (SelectablePlugin, error) {
+	if len(cands) == 0 {
+		return nil, ErrPoolEmpty
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	c := cands[r.next%len(cands)]
+	r.next++
+	return c, nil
+}
+
+// Sticky pins subscriptions to the first candidate ever selected, falling
+// back to another running candidate only if the pinned one disappears.
+type Sticky struct {
+	mutex  sync.Mutex
+	pinned SelectablePlugin
+}
+
+func (s *Sticky) Select(cands []SelectablePlugin) (SelectablePlugin, error) {
+	if len(cands) == 0 {
+		return nil, ErrPoolEmpty
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.pinned != nil {
+		for _, c := range cands {
+			if c.Key() == s.pinned.Key() {
+				return s.pinned, nil
+			}
+		}
+	}
+	s.pinned = cands[0]
+	return s.pinned, nil
+}
+
+// Pool tracks every running version of a single plugin, keyed by
+// type:name (not type:name:version), and the subscribers routed across
+// them.
+type Pool struct {
+	mutex    sync.Mutex
+	key      string
+	versions map[int][]SelectablePlugin
+	subs     int
+	pending  int
+}
+
+// New returns an empty Pool for the given type:name key.
+func New(key string) *Pool {
+	return &Pool{
+		key:      key,
+		versions: make(map[int][]SelectablePlugin),
+	}
+}
+
+// Key returns the type:name key this pool was created for.
+func (p *Pool) Key() string {
+	return p.key
+}
+
+// Insert adds a running plugin to the pool under its reported version.
+func (p *Pool) Insert(ap SelectablePlugin) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.versions[ap.Version()] = append(p.versions[ap.Version()], ap)
+}
+
+// Remove drops a plugin from the pool, e.g. after it dies or is stopped.
+func (p *Pool) Remove(ap SelectablePlugin) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	cands := p.versions[ap.Version()]
+	for i, c := range cands {
+		if c.Key() == ap.Key() {
+			p.versions[ap.Version()] = append(cands[:i], cands[i+1:]...)
+			break
+		}
+	}
+	if len(p.versions[ap.Version()]) == 0 {
+		delete(p.versions, ap.Version())
+	}
+}
+
+// EvictOldest drops and returns the least-recently-hit running plugin
+// from any version other than keep, or nil if every running instance is
+// already version keep (or the pool is empty). It only updates the
+// pool's own bookkeeping, matching Remove; the caller is responsible for
+// actually stopping the returned plugin.
+func (p *Pool) EvictOldest(keep int) SelectablePlugin {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var oldest SelectablePlugin
+	var oldestVersion int
+	for v, cands := range p.versions {
+		if v == keep {
+			continue
+		}
+		for _, c := range cands {
+			if oldest == nil || c.LastHitTime().Before(oldest.LastHitTime()) {
+				oldest = c
+				oldestVersion = v
+			}
+		}
+	}
+	if oldest == nil {
+		return nil
+	}
+
+	cands := p.versions[oldestVersion]
+	for i, c := range cands {
+		if c.Key() == oldest.Key() {
+			p.versions[oldestVersion] = append(cands[:i], cands[i+1:]...)
+			break
+		}
+	}
+	if len(p.versions[oldestVersion]) == 0 {
+		delete(p.versions, oldestVersion)
+	}
+	return oldest
+}
+
+// Version returns the highest version currently running in this pool, or
+// 0 if the pool is empty.
+func (p *Pool) Version() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	max := 0
+	for v := range p.versions {
+		if v > max {
+			max = v
+		}
+	}
+	return max
+}
+
+// Count returns the number of running plugins across all versions in the
+// pool, optionally narrowed to a single version when version > 0.
+func (p *Pool) Count(version int) int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if version > 0 {
+		return len(p.versions[version])
+	}
+	n := 0
+	for _, cands := range p.versions {
+		n += len(cands)
+	}
+	return n
+}
+
+// SelectAP chooses a running plugin to service the next subscription,
+// pinning to an exact version when version > 0 or floating to the newest
+// available version otherwise, and records the selection as a hit.
+func (p *Pool) SelectAP(s Strategy, version int) (SelectablePlugin, error) {
+	p.mutex.Lock()
+	v := version
+	if v <= 0 {
+		max := 0
+		for ver := range p.versions {
+			if ver > max {
+				max = ver
+			}
+		}
+		v = max
+	}
+	cands := p.versions[v]
+	p.mutex.Unlock()
+
+	selected, err := s.Select(cands)
+	if err != nil {
+		return nil, err
+	}
+	selected.Hit()
+	return selected, nil
+}
+
+// Subscribe registers a new subscriber against this pool and returns the
+// resulting subscriber count.
+func (p *Pool) Subscribe() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.subs++
+	return p.subs
+}
+
+// Unsubscribe removes a subscriber from this pool and returns the
+// resulting subscriber count. It floors at zero rather than going
+// negative if called more times than Subscribe.
+func (p *Pool) Unsubscribe() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.subs > 0 {
+		p.subs--
+	}
+	return p.subs
+}
+
+// Subscribers returns the current subscriber count.
+func (p *Pool) Subscribers() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.subs
+}
+
+// Reserve atomically claims up to want additional slots against max,
+// accounting for both plugins already running and slots already reserved
+// by a concurrent caller, and returns how many slots were actually
+// granted. Every granted reservation must be matched by a later Release
+// of the same amount once the caller's start attempts have finished
+// (whether they succeeded or failed).
+func (p *Pool) Reserve(max, want int) int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	running := 0
+	for _, cands := range p.versions {
+		running += len(cands)
+	}
+	avail := max - running - p.pending
+	if avail <= 0 {
+		return 0
+	}
+	if want > avail {
+		want = avail
+	}
+	p.pending += want
+	return want
+}
+
+// Release returns n previously Reserve'd slots. Successful starts are
+// already reflected in Count via Insert by the time Release is called, so
+// this simply un-claims the reservation rather than adjusting Count.
+func (p *Pool) Release(n int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.pending -= n
+	if p.pending < 0 {
+		p.pending = 0
+	}
+}
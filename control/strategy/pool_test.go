@@ -0,0 +1,225 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+)
+
+type mockPlugin struct {
+	key     string
+	version int
+	hits    int
+	lastHit time.Time
+}
+
+func (m *mockPlugin) Key() string             { return m.key }
+func (m *mockPlugin) Version() int            { return m.version }
+func (m *mockPlugin) HitCount() int           { return m.hits }
+func (m *mockPlugin) LastHitTime() time.Time  { return m.lastHit }
+func (m *mockPlugin) Hit() {
+	m.hits++
+	m.lastHit = time.Now()
+}
+
+func TestPoolInsertRemoveCount(t *testing.T) {
+	p := New("collector:foo")
+	a := &mockPlugin{key: "collector:foo:1:a", version: 1}
+	b := &mockPlugin{key: "collector:foo:1:b", version: 1}
+	c := &mockPlugin{key: "collector:foo:2:c", version: 2}
+
+	p.Insert(a)
+	p.Insert(b)
+	p.Insert(c)
+
+	if n := p.Count(0); n != 3 {
+		t.Fatalf("expected 3 running across all versions, got %d", n)
+	}
+	if n := p.Count(1); n != 2 {
+		t.Fatalf("expected 2 running at version 1, got %d", n)
+	}
+	if v := p.Version(); v != 2 {
+		t.Fatalf("expected newest version 2, got %d", v)
+	}
+
+	p.Remove(a)
+	if n := p.Count(1); n != 1 {
+		t.Fatalf("expected 1 running at version 1 after remove, got %d", n)
+	}
+
+	p.Remove(b)
+	if n := p.Count(1); n != 0 {
+		t.Fatalf("expected 0 running at version 1 after removing all, got %d", n)
+	}
+	if v := p.Version(); v != 2 {
+		t.Fatalf("expected newest version to still be 2, got %d", v)
+	}
+}
+
+func TestPoolSelectAPRecordsHit(t *testing.T) {
+	p := New("publisher:foo")
+	a := &mockPlugin{key: "publisher:foo:1:a", version: 1}
+	p.Insert(a)
+
+	selected, err := p.SelectAP(LRU{}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if selected.Key() != a.key {
+		t.Fatalf("expected %s selected, got %s", a.key, selected.Key())
+	}
+	if a.HitCount() != 1 {
+		t.Fatalf("expected SelectAP to record a hit, got HitCount %d", a.HitCount())
+	}
+}
+
+func TestPoolSelectAPFloatsToNewestVersion(t *testing.T) {
+	p := New("collector:foo")
+	old := &mockPlugin{key: "collector:foo:1:a", version: 1}
+	newer := &mockPlugin{key: "collector:foo:2:b", version: 2}
+	p.Insert(old)
+	p.Insert(newer)
+
+	selected, err := p.SelectAP(LRU{}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if selected.Key() != newer.key {
+		t.Fatalf("expected float to newest version %s, got %s", newer.key, selected.Key())
+	}
+}
+
+func TestPoolSelectAPPinnedVersion(t *testing.T) {
+	p := New("collector:foo")
+	old := &mockPlugin{key: "collector:foo:1:a", version: 1}
+	newer := &mockPlugin{key: "collector:foo:2:b", version: 2}
+	p.Insert(old)
+	p.Insert(newer)
+
+	selected, err := p.SelectAP(LRU{}, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if selected.Key() != old.key {
+		t.Fatalf("expected pinned version 1 plugin %s, got %s", old.key, selected.Key())
+	}
+}
+
+func TestPoolSelectAPEmptyPool(t *testing.T) {
+	p := New("collector:foo")
+	if _, err := p.SelectAP(LRU{}, 0); err != ErrPoolEmpty {
+		t.Fatalf("expected ErrPoolEmpty, got %v", err)
+	}
+}
+
+func TestLRUSelectsLeastRecentlyHit(t *testing.T) {
+	now := time.Now()
+	a := &mockPlugin{key: "a", lastHit: now.Add(-time.Minute)}
+	b := &mockPlugin{key: "b", lastHit: now}
+
+	selected, err := (LRU{}).Select([]SelectablePlugin{a, b})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if selected.Key() != "a" {
+		t.Fatalf("expected least recently hit plugin 'a', got %s", selected.Key())
+	}
+}
+
+func TestRoundRobinCyclesCandidates(t *testing.T) {
+	a := &mockPlugin{key: "a"}
+	b := &mockPlugin{key: "b"}
+	cands := []SelectablePlugin{a, b}
+	rr := &RoundRobin{}
+
+	first, _ := rr.Select(cands)
+	second, _ := rr.Select(cands)
+	third, _ := rr.Select(cands)
+
+	if first.Key() != "a" || second.Key() != "b" || third.Key() != "a" {
+		t.Fatalf("expected a,b,a rotation, got %s,%s,%s", first.Key(), second.Key(), third.Key())
+	}
+}
+
+func TestStickyPinsToFirstSelection(t *testing.T) {
+	a := &mockPlugin{key: "a"}
+	b := &mockPlugin{key: "b"}
+	cands := []SelectablePlugin{a, b}
+	s := &Sticky{}
+
+	first, _ := s.Select(cands)
+	second, _ := s.Select(cands)
+
+	if first.Key() != second.Key() {
+		t.Fatalf("expected sticky selection to stay on %s, got %s", first.Key(), second.Key())
+	}
+}
+
+func TestPoolSubscribeUnsubscribe(t *testing.T) {
+	p := New("collector:foo")
+	p.Subscribe()
+	p.Subscribe()
+	if n := p.Subscribers(); n != 2 {
+		t.Fatalf("expected 2 subscribers, got %d", n)
+	}
+
+	p.Unsubscribe()
+	if n := p.Subscribers(); n != 1 {
+		t.Fatalf("expected 1 subscriber after unsubscribe, got %d", n)
+	}
+
+	p.Unsubscribe()
+	p.Unsubscribe()
+	if n := p.Subscribers(); n != 0 {
+		t.Fatalf("expected subscribers to floor at 0, got %d", n)
+	}
+}
+
+func TestPoolReserveRelease(t *testing.T) {
+	p := New("collector:foo")
+	p.Insert(&mockPlugin{key: "collector:foo:1:a", version: 1})
+
+	got := p.Reserve(3, 5)
+	if got != 2 {
+		t.Fatalf("expected reservation capped at remaining capacity (2), got %d", got)
+	}
+
+	// A concurrent reservation should see no capacity left.
+	if got := p.Reserve(3, 1); got != 0 {
+		t.Fatalf("expected no capacity left while reservation outstanding, got %d", got)
+	}
+
+	p.Release(2)
+	if got := p.Reserve(3, 1); got != 1 {
+		t.Fatalf("expected capacity back after release, got %d", got)
+	}
+}
+
+func TestPoolEvictOldestSkipsKeptVersion(t *testing.T) {
+	p := New("collector:foo")
+	older := &mockPlugin{key: "collector:foo:1:a", version: 1, lastHit: time.Now().Add(-time.Hour)}
+	newer := &mockPlugin{key: "collector:foo:1:b", version: 1, lastHit: time.Now()}
+	kept := &mockPlugin{key: "collector:foo:2:c", version: 2, lastHit: time.Now().Add(-time.Hour * 2)}
+	p.Insert(older)
+	p.Insert(newer)
+	p.Insert(kept)
+
+	victim := p.EvictOldest(2)
+	if victim == nil || victim.Key() != older.key {
+		t.Fatalf("expected oldest non-kept-version instance (%s) evicted, got %v", older.key, victim)
+	}
+	if n := p.Count(0); n != 2 {
+		t.Fatalf("expected 2 remaining after eviction, got %d", n)
+	}
+	if n := p.Count(2); n != 1 {
+		t.Fatalf("expected kept version untouched, got %d", n)
+	}
+}
+
+func TestPoolEvictOldestNilWhenOnlyKeptVersionRunning(t *testing.T) {
+	p := New("collector:foo")
+	p.Insert(&mockPlugin{key: "collector:foo:2:a", version: 2})
+
+	if victim := p.EvictOldest(2); victim != nil {
+		t.Fatalf("expected no eviction when only the kept version is running, got %v", victim)
+	}
+}
@@ -0,0 +1,100 @@
+// Package control_event defines the gomit event bodies emitted by the
+// control package.
+package control_event
+
+// DeadAvailablePluginEvent is emitted by the monitor when a running
+// plugin fails enough consecutive health checks to be considered dead.
+// The runner reacts by removing it from its pool and, if the pool falls
+// below the minimum required for existing subscriptions, starting a
+// replacement.
+type DeadAvailablePluginEvent struct {
+	Name    string
+	Version int
+	Type    int
+	Key     string
+	String  string
+}
+
+// Namespace returns the event's gomit namespace.
+func (e *DeadAvailablePluginEvent) Namespace() string {
+	return "control.PluginDied"
+}
+
+// PluginDisabledEvent is emitted when a plugin exceeds its restart budget
+// and is given up on rather than restarted again.
+type PluginDisabledEvent struct {
+	Key string
+}
+
+// Namespace returns the event's gomit namespace.
+func (e *PluginDisabledEvent) Namespace() string {
+	return "control.PluginDisabled"
+}
+
+// PluginStartFailedEvent is emitted when a plugin could not be started,
+// instead of the daemon panicking. ErrorClass is one of "start",
+// "response", "ping" or "unknown", describing where in the startup
+// sequence the failure occurred, or "disabled" when the start was refused
+// because the plugin is disabled; Attempt is the 1-indexed start attempt
+// that failed, or 0 for a "disabled" refusal.
+type PluginStartFailedEvent struct {
+	Key        string
+	Error      string
+	ErrorClass string
+	Attempt    int
+}
+
+// Namespace returns the event's gomit namespace.
+func (e *PluginStartFailedEvent) Namespace() string {
+	return "control.PluginStartFailed"
+}
+
+// PluginSubscriptionFailedEvent is emitted when a subscription event
+// could not be resolved to a plugin to start, e.g. no loaded plugin
+// matches the requested metric or publisher.
+type PluginSubscriptionFailedEvent struct {
+	PluginName    string
+	PluginVersion int
+	Error         string
+}
+
+// Namespace returns the event's gomit namespace.
+func (e *PluginSubscriptionFailedEvent) Namespace() string {
+	return "control.PluginSubscriptionFailed"
+}
+
+// PublisherUnsubscriptionEvent is emitted when a publisher subscription
+// ends, the counterpart to PublisherSubscriptionEvent.
+type PublisherUnsubscriptionEvent struct {
+	PluginName    string
+	PluginVersion int
+}
+
+// Namespace returns the event's gomit namespace.
+func (e *PublisherUnsubscriptionEvent) Namespace() string {
+	return "control.PluginPublisherUnsubscriptionEvent"
+}
+
+// MetricUnsubscriptionEvent is emitted when a metric subscription ends,
+// the counterpart to MetricSubscriptionEvent.
+type MetricUnsubscriptionEvent struct {
+	MetricNamespace []string
+	Version         int
+}
+
+// Namespace returns the event's gomit namespace.
+func (e *MetricUnsubscriptionEvent) Namespace() string {
+	return "control.MetricUnsubscriptionEvent"
+}
+
+// PluginStopEvent is emitted once a running plugin has been stopped,
+// whether as part of a graceful runner.Stop() drain or when an instance
+// of another version is evicted to make room for a new subscription.
+type PluginStopEvent struct {
+	Key string
+}
+
+// Namespace returns the event's gomit namespace.
+func (e *PluginStopEvent) Namespace() string {
+	return "control.PluginStopped"
+}